@@ -0,0 +1,106 @@
+package introspector
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// IntrospectorOption configures an Introspector at construction time, via
+// NewIntrospector.
+type IntrospectorOption[F any] func(*introspector[F])
+
+// WithCacheDisabled disables the per-function introspection cache, so every
+// call to Introspect re-walks fn's parameters instead of reusing a
+// previously computed result. Use this when the same function value may
+// resolve to different factories across calls without the Introspector
+// being reconfigured, e.g. a default factory whose answer for a type changes
+// over time. SetDefaultFactory, SetParent and RegisterFactory already clear
+// the cache themselves.
+func WithCacheDisabled[F any]() IntrospectorOption[F] {
+	return func(i *introspector[F]) {
+		i.cacheDisabled = true
+	}
+}
+
+// cacheKey identifies a previously introspected function by its code
+// pointer. ptr alone isn't safe to use once the original fn is no longer
+// reachable, since the Go runtime is free to reuse the same code pointer for
+// a later, unrelated function value, so typ is kept alongside it as a
+// tiebreaker. extraArgs distinguishes Invoke calls that leave different sets
+// of parameters unresolved (see coveredByExtraArg) from a plain Introspect
+// call and from each other, so they don't collide in the same cache entry.
+type cacheKey struct {
+	ptr       uintptr
+	typ       reflect.Type
+	extraArgs string
+}
+
+// extraArgsSignature builds a cacheKey-comparable signature for a set of
+// extraArg types, independent of the order they were passed in. An empty
+// slice (a plain Introspect call, or an Invoke call with no extraArgs)
+// signatures to "", so it shares a cache entry with a plain Introspect call
+// for the same function.
+func extraArgsSignature(types []reflect.Type) string {
+	if len(types) == 0 {
+		return ""
+	}
+	parts := make([]string, len(types))
+	for idx, t := range types {
+		parts[idx] = fmt.Sprintf("%p", t)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func (i *introspector[F]) cacheGet(key cacheKey) (*introspectorResult[F], bool) {
+	i.cacheMu.RLock()
+	defer i.cacheMu.RUnlock()
+	result, ok := i.cache[key]
+	return result, ok
+}
+
+func (i *introspector[F]) cacheSet(key cacheKey, result *introspectorResult[F]) {
+	i.cacheMu.Lock()
+	defer i.cacheMu.Unlock()
+	if i.cache == nil {
+		i.cache = make(map[cacheKey]*introspectorResult[F])
+	}
+	i.cache[key] = result
+}
+
+// ClearCache discards every cached IntrospectorResult, forcing the next
+// Introspect call for each function to re-walk its parameters and resolve
+// its factories again. It also cascades to every child introspector (one
+// that called SetParent(i)), since a child's cached result may have been
+// resolved by walking up into i's factories and would otherwise go stale.
+func (i *introspector[F]) ClearCache() {
+	i.cacheMu.Lock()
+	i.cache = nil
+	i.cacheMu.Unlock()
+
+	i.childrenMu.Lock()
+	children := append([]*introspector[F](nil), i.children...)
+	i.childrenMu.Unlock()
+	for _, child := range children {
+		child.ClearCache()
+	}
+}
+
+func (i *introspector[F]) addChild(child *introspector[F]) {
+	i.childrenMu.Lock()
+	defer i.childrenMu.Unlock()
+	i.children = append(i.children, child)
+}
+
+func (i *introspector[F]) removeChild(child *introspector[F]) {
+	i.childrenMu.Lock()
+	defer i.childrenMu.Unlock()
+	for idx, c := range i.children {
+		if c == child {
+			i.children = append(i.children[:idx], i.children[idx+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package introspector_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/infinytum/introspector"
+)
+
+// Write a test that verifies that Introspect caches its result for a given
+// function, so the default factory is not consulted again on a later call.
+//
+// The test should:
+// - set a default factory that counts its calls
+// - introspect the same function twice
+// - verify the default factory was only consulted once
+func TestIntrospectCachesResult(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		calls++
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	if _, errs := i.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if _, errs := i.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected default factory to be consulted once, got %d", calls)
+	}
+}
+
+// Write a test that verifies that WithCacheDisabled turns off the
+// introspection cache, so the default factory is consulted on every call.
+//
+// The test should:
+// - create an Introspector with WithCacheDisabled
+// - set a default factory that counts its calls
+// - introspect the same function twice
+// - verify the default factory was consulted both times
+func TestWithCacheDisabled(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult](introspector.WithCacheDisabled[TestFactoryFunc]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		calls++
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	if _, errs := i.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if _, errs := i.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected default factory to be consulted on every call, got %d", calls)
+	}
+}
+
+// Write a test that verifies that RegisterFactory invalidates cached
+// introspection results, so a function introspected before the call picks up
+// the newly registered factory afterward.
+//
+// The test should:
+// - introspect a function depending on int, resolved via the default factory
+// - register an explicit factory for int
+// - introspect the same function again
+// - verify the explicit factory, not the stale default one, was used
+func TestRegisterFactoryInvalidatesCache(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(1)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	res, errs := i.Introspect(fn)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if got := res.FactoryMap()[0](1).Interface().(int); got != 1 {
+		t.Fatalf("expected default factory's value 1, got %d", got)
+	}
+
+	introspector.RegisterFactory[int](func(i int) reflect.Value {
+		return reflect.ValueOf(2)
+	}, i)
+
+	res, errs = i.Introspect(fn)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if got := res.FactoryMap()[0](1).Interface().(int); got != 2 {
+		t.Fatalf("expected registered factory's value 2 after cache invalidation, got %d", got)
+	}
+}
+
+// Write a test that verifies that ClearCache forces the next Introspect call
+// to re-walk a function's parameters.
+//
+// The test should:
+// - set a default factory that counts its calls
+// - introspect a function, call ClearCache, then introspect it again
+// - verify the default factory was consulted twice
+func TestClearCache(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		calls++
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	if _, errs := i.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	i.ClearCache()
+	if _, errs := i.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected default factory to be consulted again after ClearCache, got %d", calls)
+	}
+}
+
+// Write a test that verifies that a child introspector's cache is
+// invalidated when its parent's default factory changes, since the child's
+// cached result may have been resolved by walking up into the parent.
+//
+// The test should:
+//   - give the parent a default factory returning 1, and the child none of
+//     its own
+//   - introspect a function depending on int using the child
+//   - change the parent's default factory to return 2
+//   - introspect the same function using the child again
+//   - verify the child picked up the parent's new factory instead of the
+//     stale cached result
+func TestSetDefaultFactoryInvalidatesChildCache(t *testing.T) {
+	parent, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(1)
+		}, nil
+	})
+
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child.SetParent(parent)
+
+	fn := func(i int) {}
+	res, errs := child.Introspect(fn)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if got := res.FactoryMap()[0](1).Interface().(int); got != 1 {
+		t.Fatalf("expected parent's first factory value 1, got %d", got)
+	}
+
+	parent.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(2)
+		}, nil
+	})
+
+	res, errs = child.Introspect(fn)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if got := res.FactoryMap()[0](1).Interface().(int); got != 2 {
+		t.Fatalf("expected parent's updated factory value 2 after cache invalidation, got %d", got)
+	}
+}
+
+// Write a test that verifies that re-parenting a child introspector removes
+// it from its old parent's children, so the old parent no longer cascades
+// cache invalidation to it.
+//
+// The test should:
+//   - set the child's parent to parentA, then re-parent it to parentB
+//   - introspect a function depending on int using the child, caching it
+//   - clear parentA's cache and verify the child's cache survived (it
+//     wasn't cascaded to, since it's no longer parentA's child)
+//   - clear parentB's cache and verify the child's cache was cleared (it
+//     was cascaded to, since it's parentB's child now)
+func TestSetParentRemovesChildFromOldParent(t *testing.T) {
+	parentA, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parentB, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	child.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		calls++
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	child.SetParent(parentA)
+	child.SetParent(parentB)
+
+	fn := func(i int) {}
+	if _, errs := child.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	parentA.ClearCache()
+	if _, errs := child.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if calls != 1 {
+		t.Fatalf("expected child's cache to survive its old parent's ClearCache, but default factory was consulted %d times", calls)
+	}
+
+	parentB.ClearCache()
+	if _, errs := child.Introspect(fn); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if calls != 2 {
+		t.Fatalf("expected child's cache to be cleared by its current parent's ClearCache, but default factory was consulted %d times", calls)
+	}
+}
+
+// BenchmarkIntrospect measures repeated Introspect calls for the same
+// function with the cache enabled, where only the first call pays for the
+// reflective parameter walk.
+func BenchmarkIntrospect(b *testing.B) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		b.Fatal(err)
+	}
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, errs := i.Introspect(fn); len(errs) != 0 {
+			b.Fatal(errs)
+		}
+	}
+}
+
+// BenchmarkIntrospectUncached measures the same workload with the cache
+// disabled, re-walking fn's parameters on every call.
+func BenchmarkIntrospectUncached(b *testing.B) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult](introspector.WithCacheDisabled[TestFactoryFunc]())
+	if err != nil {
+		b.Fatal(err)
+	}
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, errs := i.Introspect(fn); len(errs) != 0 {
+			b.Fatal(errs)
+		}
+	}
+}
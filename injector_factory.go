@@ -1,18 +1,167 @@
 package introspector
 
 import (
+	"errors"
 	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/infinytum/injector"
 )
 
+// ErrorInjectionCycle is returned by InjectorFactoryFunc when a struct's
+// injector-tagged fields recursively reference their own type, directly or
+// through an intermediate struct.
+var ErrorInjectionCycle = errors.New("introspector: cyclic struct injection detected")
+
+var (
+	namedSingletonsMu sync.RWMutex
+	namedSingletons   = map[FactoryKey]reflect.Value{}
+)
+
+// RegisterNamedSingleton registers value as the dependency resolved for
+// fields tagged injector:"name=name" (or injector:"qualifier=name") of type
+// T, for use by InjectorFactoryFunc's struct-fill branch. Unlike
+// RegisterNamedFactory, this isn't scoped to a particular Introspector, since
+// InjectorFactoryFunc has no Introspector of its own to consult - it mirrors
+// the package-level nature of injector.Singleton.
+func RegisterNamedSingleton[T any](name string, value T) {
+	namedSingletonsMu.Lock()
+	defer namedSingletonsMu.Unlock()
+	namedSingletons[FactoryKey{Type: reflect.TypeOf(new(T)).Elem(), Name: name}] = reflect.ValueOf(value)
+}
+
+func resolveNamedSingleton(t reflect.Type, name string) (reflect.Value, bool) {
+	namedSingletonsMu.RLock()
+	defer namedSingletonsMu.RUnlock()
+	v, ok := namedSingletons[FactoryKey{Type: t, Name: name}]
+	return v, ok
+}
+
+// fillTagged fills every field of ctx tagged "injector" - type-only fields
+// (injector:"type") resolve through injector.InjectT as before; fields tagged
+// injector:"name=x" or injector:"qualifier=x" resolve through
+// RegisterNamedSingleton instead, so two dependencies sharing a Go type (e.g.
+// two strings) can coexist in the same context struct. A field whose type is
+// itself a struct (or pointer-to-struct) with further injector tags is
+// treated as a nested context and filled by recursing into
+// InjectorFactoryFunc instead of resolving it as a single dependency; seen
+// tracks the struct types on the current recursion path so such a cycle is
+// reported as ErrorInjectionCycle instead of overflowing the stack. Any
+// field additionally tagged injector:"optional" degrades to its zero value,
+// rather than failing the whole tree, when its dependency can't be resolved;
+// an ErrorInjectionCycle is still returned even for an optional field.
+func fillTagged(ctx any, seen map[reflect.Type]bool) error {
+	v := reflect.ValueOf(ctx).Elem()
+	t := v.Type()
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		tag, ok := field.Tag.Lookup("injector")
+		if !ok {
+			continue
+		}
+		optional := hasTagModifier(tag, "optional")
+
+		if name, ok := namedTagValue(tag); ok {
+			value, ok := resolveNamedSingleton(field.Type, name)
+			if !ok {
+				if optional {
+					continue
+				}
+				return injector.ErrorDepFactoryNotFound
+			}
+			v.Field(idx).Set(value)
+			continue
+		}
+
+		if nestedContextType(field.Type) {
+			value, err := injectorFactory(field.Type, seen)
+			if err != nil {
+				if optional && !errors.Is(err, ErrorInjectionCycle) {
+					continue
+				}
+				return err
+			}
+			v.Field(idx).Set(*value)
+			continue
+		}
+
+		value, err := injector.InjectT(field.Type)
+		if err != nil {
+			if optional {
+				continue
+			}
+			return err
+		}
+		v.Field(idx).Set(value)
+	}
+	return nil
+}
+
+// nestedContextType reports whether t (or, if t is a pointer, its pointee)
+// is a struct with at least one injector-tagged field, making it a nested
+// context to recurse into rather than a leaf dependency to resolve directly.
+func nestedContextType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for idx := 0; idx < t.NumField(); idx++ {
+		if _, ok := t.Field(idx).Tag.Lookup("injector"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func namedTagValue(tag string) (string, bool) {
+	for _, part := range strings.Split(tag, ",") {
+		if name, found := strings.CutPrefix(part, "name="); found {
+			return name, true
+		}
+		if name, found := strings.CutPrefix(part, "qualifier="); found {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func hasTagModifier(tag, modifier string) bool {
+	for _, part := range strings.Split(tag, ",") {
+		if part == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+// InjectorFactoryFunc resolves a single value of forType, used as the
+// default factory backing an Introspector built from injector singletons.
 func InjectorFactoryFunc(forType reflect.Type) (*reflect.Value, error) {
+	return injectorFactory(forType, map[reflect.Type]bool{})
+}
+
+// injectorFactory is InjectorFactoryFunc's recursive core. seen holds the
+// struct types currently being resolved on this call's recursion path, so a
+// struct field that (directly or transitively) depends on its own type is
+// reported as ErrorInjectionCycle instead of recursing forever.
+func injectorFactory(forType reflect.Type, seen map[reflect.Type]bool) (*reflect.Value, error) {
 	isPointer := false
 	if _, resolveErr := injector.InjectT(forType); resolveErr != nil && forType.Kind() == reflect.Pointer {
 		isPointer = true
 		forType = forType.Elem()
 	}
 
+	if forType.Kind() == reflect.Struct {
+		if seen[forType] {
+			return nil, ErrorInjectionCycle
+		}
+		seen[forType] = true
+		defer delete(seen, forType)
+	}
+
 	ctx := reflect.New(forType).Interface()
 	hasInjected := false
 
@@ -21,7 +170,7 @@ func InjectorFactoryFunc(forType reflect.Type) (*reflect.Value, error) {
 		if err := injector.InjectInto(ctx); err != nil {
 			// If not, structs are usually Context objects that hold one or multiple fields
 			// that must be filled with dependencies
-			if err2 := injector.Fill(ctx); err2 != nil {
+			if err2 := fillTagged(ctx, seen); err2 != nil {
 				if err != injector.ErrorDepFactoryNotFound {
 					return nil, err
 				}
@@ -30,6 +30,27 @@ type TestFaultyContext struct {
 	Dep http.ResponseWriter `injector:"type"`
 }
 
+type TestNamedContext struct {
+	Primary string `injector:"name=dsn"`
+	Replica string `injector:"qualifier=replica-dsn"`
+}
+
+type TestNestedContext struct {
+	Inner TestContext `injector:"recurse"`
+}
+
+type TestNestedPointerContext struct {
+	Inner *TestContext `injector:"recurse"`
+}
+
+type TestCyclicContext struct {
+	Self *TestCyclicContext `injector:"recurse"`
+}
+
+type TestOptionalContext struct {
+	Missing http.ResponseWriter `injector:"type,optional"`
+}
+
 type InjectorFactory func() reflect.Value
 
 type InjectorResult struct {
@@ -56,7 +77,7 @@ func TestNonPointerStructDep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	i.SetDefaultFactory(func(t reflect.Type) (InjectorFactory, error) {
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
 		val, err := introspector.InjectorFactoryFunc(t)
 		return func() reflect.Value {
 			return *val
@@ -104,7 +125,7 @@ func TestPointerStructDep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	i.SetDefaultFactory(func(t reflect.Type) (InjectorFactory, error) {
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
 		val, err := introspector.InjectorFactoryFunc(t)
 		return func() reflect.Value {
 			return *val
@@ -141,7 +162,7 @@ func TestInterfaceDep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	i.SetDefaultFactory(func(t reflect.Type) (InjectorFactory, error) {
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
 		val, err := introspector.InjectorFactoryFunc(t)
 		return func() reflect.Value {
 			return *val
@@ -172,7 +193,7 @@ func TestErrorDepFactoryNotFound(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	i.SetDefaultFactory(func(t reflect.Type) (InjectorFactory, error) {
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
 		val, err := introspector.InjectorFactoryFunc(t)
 		return func() reflect.Value {
 			return *val
@@ -202,7 +223,7 @@ func TestStructFillDep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	i.SetDefaultFactory(func(t reflect.Type) (InjectorFactory, error) {
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
 		val, err := introspector.InjectorFactoryFunc(t)
 		return func() reflect.Value {
 			return *val
@@ -247,7 +268,7 @@ func TestBuiltinDep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	i.SetDefaultFactory(func(t reflect.Type) (InjectorFactory, error) {
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
 		val, err := introspector.InjectorFactoryFunc(t)
 		return func() reflect.Value {
 			return *val
@@ -265,3 +286,165 @@ func TestBuiltinDep(t *testing.T) {
 		t.Fatal("expected dependency to be 69")
 	}
 }
+
+// Write a test that verifies that struct fields tagged injector:"name=..." or
+// injector:"qualifier=..." are filled from RegisterNamedSingleton instead of
+// the plain, type-only singleton registry.
+//
+// The test should:
+// - register two named string singletons
+// - introspect a function that depends on a context struct tagged with both
+// - verify that each field was filled with its own named singleton
+func TestNamedStructFillDep(t *testing.T) {
+	introspector.RegisterNamedSingleton("dsn", "primary-dsn")
+	introspector.RegisterNamedSingleton("replica-dsn", "replica-dsn-value")
+
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	res, errs := i.Introspect(func(d TestNamedContext) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	ctx := factory().Interface().(TestNamedContext)
+	if ctx.Primary != "primary-dsn" {
+		t.Fatalf("expected primary dsn, got %q", ctx.Primary)
+	}
+	if ctx.Replica != "replica-dsn-value" {
+		t.Fatalf("expected replica dsn, got %q", ctx.Replica)
+	}
+}
+
+// Write a test that verifies that a struct field tagged injector:"recurse"
+// whose type is itself a context struct is populated recursively.
+//
+// The test should:
+// - call the NewIntrospector function
+// - introspect a function that depends on a context with a nested value struct field
+// - verify the nested field's own dependency was resolved
+func TestNestedStructFillDep(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	res, errs := i.Introspect(func(d TestNestedContext) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	if factory().Interface().(TestNestedContext).Inner.Dep.Value != 69 {
+		t.Fatal("expected nested dependency to be 69")
+	}
+}
+
+// Write a test that verifies that a struct field tagged injector:"recurse"
+// whose type is a pointer to a context struct is populated recursively.
+//
+// The test should:
+// - call the NewIntrospector function
+// - introspect a function that depends on a context with a nested pointer struct field
+// - verify the nested field's own dependency was resolved
+func TestNestedPointerStructFillDep(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	res, errs := i.Introspect(func(d TestNestedPointerContext) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	if factory().Interface().(TestNestedPointerContext).Inner.Dep.Value != 69 {
+		t.Fatal("expected nested pointer dependency to be 69")
+	}
+}
+
+// Write a test that verifies that a self-referential injector:"recurse"
+// field is reported as ErrorInjectionCycle instead of recursing forever.
+//
+// The test should:
+// - call the NewIntrospector function
+// - introspect a function that depends on a context recursing into its own type
+// - verify the Introspect function returns ErrorInjectionCycle
+func TestInjectionCycleDetected(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	_, errs := i.Introspect(func(d TestCyclicContext) {})
+	if len(errs) == 0 {
+		t.Fatal("expected error")
+	}
+	if errs[0] != introspector.ErrorInjectionCycle {
+		t.Fatalf("expected ErrorInjectionCycle, got %v", errs[0])
+	}
+}
+
+// Write a test that verifies that a field tagged injector:"optional"
+// degrades to its zero value instead of failing the whole tree when its
+// dependency can't be resolved.
+//
+// The test should:
+// - call the NewIntrospector function
+// - introspect a function that depends on a context with an unresolvable optional field
+// - verify the Introspect function succeeds with the field left at its zero value
+func TestOptionalDepFallback(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	res, errs := i.Introspect(func(d TestOptionalContext) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	if factory().Interface().(TestOptionalContext).Missing != nil {
+		t.Fatal("expected optional dependency to be left at its zero value")
+	}
+}
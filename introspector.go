@@ -0,0 +1,382 @@
+package introspector
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/infinytum/injector"
+)
+
+// ErrorInvalidResultType is returned by NewIntrospector when the given result
+// type does not anonymously embed an IntrospectorResult of the requested
+// factory type.
+var ErrorInvalidResultType = errors.New("introspector: result type must embed IntrospectorResult")
+
+// ErrorNotAFunction is returned by Introspect when the given value is not a func.
+var ErrorNotAFunction = errors.New("introspector: value is not a function")
+
+// ErrorFactoryNotFound is returned when no factory, explicit or default, could
+// be resolved for a parameter type.
+var ErrorFactoryNotFound = errors.New("introspector: no factory found for type")
+
+// DefaultFactoryFunc produces a factory of type F for a given parameter type
+// and name, used as a fallback whenever no explicit factory has been
+// registered. name is empty for an ordinary, unqualified dependency and only
+// set when the parameter was wrapped in Named[T, Tag].
+type DefaultFactoryFunc[F any] func(t reflect.Type, name string) (F, error)
+
+// FactoryKey identifies a registered factory by dependency type and an
+// optional qualifier. Name is empty for ordinary, type-only bindings and
+// only set for bindings registered through RegisterNamedFactory.
+type FactoryKey struct {
+	Type reflect.Type
+	Name string
+}
+
+// IntrospectorResult holds the factories resolved for each parameter of an
+// introspected function, keyed by parameter index.
+type IntrospectorResult[F any] interface {
+	FactoryMap() map[int]F
+	Type() reflect.Type
+
+	// VariadicIndex returns the parameter index of fn's variadic parameter,
+	// or -1 if fn is not variadic. When set, the factory at that index
+	// produces the full variadic slice value (e.g. []int for ...int), so
+	// Invoke passes it via reflect.Value.CallSlice rather than Call.
+	VariadicIndex() int
+
+	// Invoke calls fn, which must be the exact function this result was
+	// produced for, by calling each resolved factory to build its argument.
+	// extraArgs may override specific positions by type match. Factories are
+	// expected to follow the func() reflect.Value convention established by
+	// InjectorFactoryFunc; anything else yields ErrorNotCallable.
+	Invoke(fn any, extraArgs ...reflect.Value) ([]reflect.Value, error)
+}
+
+// Introspector resolves the dependencies of a function by walking its
+// parameter list and looking up a factory for each parameter type, either
+// from an explicit FactoryMap or from a default factory.
+//
+// An Introspector may have a parent. If a parameter type can't be resolved
+// locally because the default factory reports injector.ErrorDepFactoryNotFound,
+// Introspect walks up the parent chain - checking each parent's FactoryMap
+// first, then its default factory - before giving up. This lets an app-wide
+// Introspector register singletons while each request builds a child that
+// only maps request-scoped factories, without mutating the parent.
+type Introspector[F any] interface {
+	FactoryMap() map[FactoryKey]F
+	SetDefaultFactory(factory DefaultFactoryFunc[F])
+	Introspect(fn any) (IntrospectorResult[F], []error)
+	SetParent(parent Introspector[F])
+	Parent() Introspector[F]
+
+	// ClearCache discards every cached IntrospectorResult, forcing the next
+	// Introspect call for each function to re-walk its parameters and
+	// resolve its factories again. It cascades to every child Introspector
+	// (one that called SetParent(this)), since a child's cached result may
+	// have been resolved by walking up into this Introspector's factories.
+	// See IntrospectorOption and WithCacheDisabled to opt a whole
+	// Introspector out of caching instead.
+	ClearCache()
+
+	// Invoke introspects fn the way Introspect does, except that a parameter
+	// matched by one of extraArgs is not resolved through a factory, and on
+	// success calls it with each argument built by its factory or taken from
+	// extraArgs. See IntrospectorResult.Invoke.
+	Invoke(fn any, extraArgs ...reflect.Value) ([]reflect.Value, error)
+}
+
+type introspectorResult[F any] struct {
+	factoryMap    map[int]F
+	fnType        reflect.Type
+	variadicIndex int
+}
+
+func (r *introspectorResult[F]) FactoryMap() map[int]F {
+	return r.factoryMap
+}
+
+func (r *introspectorResult[F]) Type() reflect.Type {
+	return r.fnType
+}
+
+func (r *introspectorResult[F]) VariadicIndex() int {
+	return r.variadicIndex
+}
+
+type introspector[F any] struct {
+	// mu guards factoryMap, defaultFactory and parent, all of which can be
+	// read by a concurrent Introspect/resolve call while another goroutine
+	// reconfigures this Introspector (e.g. a request-scoped child calling
+	// SetParent while the app-wide parent registers a new factory).
+	mu             sync.RWMutex
+	factoryMap     map[FactoryKey]F
+	defaultFactory DefaultFactoryFunc[F]
+	resultType     reflect.Type
+	resultField    int
+	parent         Introspector[F]
+
+	cacheMu       sync.RWMutex
+	cache         map[cacheKey]*introspectorResult[F]
+	cacheDisabled bool
+
+	// childrenMu guards children, the set of introspectors that called
+	// SetParent(i). It's kept so ClearCache can cascade: a child's cached
+	// result may have been resolved via parent fallback, so it goes stale
+	// whenever i's own factories do.
+	childrenMu sync.Mutex
+	children   []*introspector[F]
+}
+
+// NewIntrospector creates an Introspector producing results of type R, where
+// R must be a struct that anonymously embeds IntrospectorResult[F]. opts
+// configure the Introspector at construction time; see WithCacheDisabled.
+func NewIntrospector[F any, R any](opts ...IntrospectorOption[F]) (Introspector[F], error) {
+	var resultIface IntrospectorResult[F]
+	ifaceType := reflect.TypeOf(&resultIface).Elem()
+
+	var r R
+	resultType := reflect.TypeOf(r)
+	if resultType == nil || resultType.Kind() != reflect.Struct {
+		return nil, ErrorInvalidResultType
+	}
+
+	fieldIndex := -1
+	for i := 0; i < resultType.NumField(); i++ {
+		field := resultType.Field(i)
+		if field.Anonymous && field.Type == ifaceType {
+			fieldIndex = i
+			break
+		}
+	}
+	if fieldIndex == -1 {
+		return nil, ErrorInvalidResultType
+	}
+
+	i := &introspector[F]{
+		factoryMap:  make(map[FactoryKey]F),
+		resultType:  resultType,
+		resultField: fieldIndex,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i, nil
+}
+
+// RegisterFactory registers a factory for dependency type T on the given
+// Introspector. factory is accepted as any so callers can pass a bare
+// function literal without it first being converted to F.
+func RegisterFactory[T any, F any](factory any, i Introspector[F]) {
+	RegisterNamedFactory[T]("", factory, i)
+}
+
+// RegisterNamedFactory registers a factory for dependency type T under the
+// given name on the given Introspector, for use alongside a Named[T, Tag]
+// function parameter whose Tag.Name() returns the same name. factory is
+// accepted as any so callers can pass a bare function literal without it
+// first being converted to F.
+func RegisterNamedFactory[T any, F any](name string, factory any, i Introspector[F]) {
+	var zero F
+	converted := reflect.ValueOf(factory).Convert(reflect.TypeOf(zero)).Interface().(F)
+	key := FactoryKey{Type: reflect.TypeOf(new(T)).Elem(), Name: name}
+	if impl, ok := i.(*introspector[F]); ok {
+		impl.registerFactory(key, converted)
+		return
+	}
+	i.FactoryMap()[key] = converted
+	i.ClearCache()
+}
+
+// FactoryMap returns i's own factory map. It's exposed so RegisterFactory and
+// RegisterNamedFactory can be free functions rather than methods, but direct
+// writes into the returned map race with a concurrent Introspect/resolve
+// call the way writes to it from RegisterFactory/RegisterNamedFactory no
+// longer do - prefer those over indexing into FactoryMap() directly.
+func (i *introspector[F]) FactoryMap() map[FactoryKey]F {
+	return i.factoryMap
+}
+
+// registerFactory sets factoryMap[key], synchronized against concurrent
+// resolve calls, then invalidates the cache.
+func (i *introspector[F]) registerFactory(key FactoryKey, factory F) {
+	i.mu.Lock()
+	i.factoryMap[key] = factory
+	i.mu.Unlock()
+	i.ClearCache()
+}
+
+func (i *introspector[F]) SetDefaultFactory(factory DefaultFactoryFunc[F]) {
+	i.mu.Lock()
+	i.defaultFactory = factory
+	i.mu.Unlock()
+	i.ClearCache()
+}
+
+func (i *introspector[F]) SetParent(parent Introspector[F]) {
+	i.mu.Lock()
+	old, wasIntrospector := i.parent.(*introspector[F])
+	i.parent = parent
+	i.mu.Unlock()
+
+	if wasIntrospector {
+		old.removeChild(i)
+	}
+	if p, ok := parent.(*introspector[F]); ok {
+		p.addChild(i)
+	}
+	i.ClearCache()
+}
+
+func (i *introspector[F]) Parent() Introspector[F] {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.parent
+}
+
+func (i *introspector[F]) Introspect(fn any) (IntrospectorResult[F], []error) {
+	return i.introspect(fn, nil)
+}
+
+// introspect is Introspect's core, also used by Invoke so the two share the
+// same cache. extraArgTypes lists the types of values a caller will supply
+// directly at call time (see Invoke); a parameter whose type one of them is
+// assignable to is left unresolved here rather than requiring a factory for
+// it, since Invoke always prefers a matching extraArg over a factory anyway.
+func (i *introspector[F]) introspect(fn any, extraArgTypes []reflect.Type) (IntrospectorResult[F], []error) {
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func {
+		return nil, []error{ErrorNotAFunction}
+	}
+	fnType := fnVal.Type()
+
+	var key cacheKey
+	if !i.cacheDisabled {
+		key = cacheKey{ptr: fnVal.Pointer(), typ: fnType, extraArgs: extraArgsSignature(extraArgTypes)}
+		if cached, ok := i.cacheGet(key); ok {
+			result, err := i.wrapResult(cached)
+			if err != nil {
+				return nil, []error{err}
+			}
+			return result, nil
+		}
+	}
+
+	variadicIndex := -1
+	if fnType.IsVariadic() {
+		variadicIndex = fnType.NumIn() - 1
+	}
+
+	factories := make(map[int]F, fnType.NumIn())
+	var errs []error
+	for idx := 0; idx < fnType.NumIn(); idx++ {
+		paramType := fnType.In(idx)
+
+		if coveredByExtraArg(extraArgTypes, paramType) {
+			continue
+		}
+
+		if idx == variadicIndex {
+			factory, err := i.resolveVariadic(paramType)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			factories[idx] = factory
+			continue
+		}
+
+		innerType, name, named := unwrapNamedType(paramType)
+		if !named {
+			factory, err := i.resolve(paramType, "")
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			factories[idx] = factory
+			continue
+		}
+
+		innerFactory, err := i.resolve(innerType, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		factories[idx] = wrapNamedFactory(innerFactory, paramType)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	computed := &introspectorResult[F]{factoryMap: factories, fnType: fnType, variadicIndex: variadicIndex}
+	if !i.cacheDisabled {
+		i.cacheSet(key, computed)
+	}
+
+	result, err := i.wrapResult(computed)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return result, nil
+}
+
+// resolve looks up a factory for (paramType, name), first in the local
+// FactoryMap, then via the local default factory. If the default factory
+// reports injector.ErrorDepFactoryNotFound (or none is set) and a parent is
+// present, resolution is delegated to the parent, which applies the same
+// rules in turn. factoryMap, defaultFactory and parent are read under mu, so
+// resolve is safe to call concurrently with SetDefaultFactory, SetParent and
+// RegisterFactory/RegisterNamedFactory on the same Introspector.
+func (i *introspector[F]) resolve(paramType reflect.Type, name string) (F, error) {
+	i.mu.RLock()
+	factory, ok := i.factoryMap[FactoryKey{Type: paramType, Name: name}]
+	defaultFactory := i.defaultFactory
+	parent := i.parent
+	i.mu.RUnlock()
+
+	if ok {
+		return factory, nil
+	}
+
+	var err error
+	if defaultFactory == nil {
+		err = ErrorFactoryNotFound
+	} else {
+		factory, err = defaultFactory(paramType, name)
+		if err == nil {
+			return factory, nil
+		}
+	}
+
+	canWalkUp := defaultFactory == nil || errors.Is(err, injector.ErrorDepFactoryNotFound)
+	if canWalkUp {
+		if p, ok := parent.(*introspector[F]); ok {
+			return p.resolve(paramType, name)
+		}
+	}
+
+	var zero F
+	return zero, err
+}
+
+// coveredByExtraArg reports whether one of extraArgTypes is assignable to
+// paramType, using the same rule findExtraArg applies at call time.
+func coveredByExtraArg(extraArgTypes []reflect.Type, paramType reflect.Type) bool {
+	for _, t := range extraArgTypes {
+		if t.AssignableTo(paramType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *introspector[F]) wrapResult(result *introspectorResult[F]) (IntrospectorResult[F], error) {
+	v := reflect.New(i.resultType).Elem()
+	v.Field(i.resultField).Set(reflect.ValueOf(result))
+	wrapped, ok := v.Interface().(IntrospectorResult[F])
+	if !ok {
+		return nil, ErrorInvalidResultType
+	}
+	return wrapped, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/infinytum/injector"
@@ -86,7 +87,7 @@ func TestRegisterFactory(t *testing.T) {
 	if len(i.FactoryMap()) != 1 {
 		t.Fatal("expected factory map to have one entry")
 	}
-	factory, ok := i.FactoryMap()[reflect.TypeOf(int(1))]
+	factory, ok := i.FactoryMap()[introspector.FactoryKey{Type: reflect.TypeOf(int(1))}]
 	if !ok {
 		t.Fatal("expected factory map to have entry for type int")
 	}
@@ -110,7 +111,7 @@ func TestSetDefaultFactory(t *testing.T) {
 	injector.Singleton(func() url.URL {
 		return u
 	})
-	i.SetDefaultFactory(func(r reflect.Type) (TestFactoryFunc, error) {
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
 		val, err := introspector.InjectorFactoryFunc(r)
 		return func(i int) reflect.Value {
 			return *val
@@ -197,10 +198,12 @@ func TestIntrospectNoFactory(t *testing.T) {
 	}
 }
 
-// Write a test that verifies that the Introspect function returns an error if the function has a variadic parameter.
+// Write a test that verifies that the Introspect function returns an error if
+// a variadic parameter cannot be resolved either as a slice or as a single
+// element.
 //
 // The test should:
-// - create an instance of the Introspector interface
+// - create an instance of the Introspector interface with no factories registered
 // - call the Introspect function with a function that has a variadic parameter
 // - verify that the Introspect function returns an error
 func TestIntrospectVariadic(t *testing.T) {
@@ -214,6 +217,114 @@ func TestIntrospectVariadic(t *testing.T) {
 	}
 }
 
+// Write a test that verifies that a variadic parameter is resolved against a
+// factory registered for its slice type, so a whole batch can be supplied at
+// once.
+//
+// The test should:
+// - register a factory for []int
+// - introspect a function taking a variadic ...int parameter
+// - verify the resolved factory produced the registered slice
+// - verify VariadicIndex reports the variadic parameter's index
+func TestIntrospectVariadicSliceFactory(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterFactory[[]int](func(i int) reflect.Value {
+		return reflect.ValueOf([]int{1, 2, 3})
+	}, i)
+
+	res, errs := i.Introspect(func(i ...int) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if res.VariadicIndex() != 0 {
+		t.Fatalf("expected variadic index 0, got %d", res.VariadicIndex())
+	}
+
+	factory := res.FactoryMap()[0]
+	got := factory(1).Interface().([]int)
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("expected slice factory to be used, got %v", got)
+	}
+}
+
+// Write a test that verifies that a variadic parameter falls back to the
+// default factory for a single element, wrapped in a one-element slice, when
+// no factory is registered for the slice type itself.
+//
+// The test should:
+// - set a default factory producing a single int
+// - introspect a function taking a variadic ...int parameter
+// - verify the resolved factory produced a one-element slice
+func TestIntrospectVariadicElementFallback(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		if r.Kind() == reflect.Slice {
+			return nil, errors.New("no batch registered")
+		}
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(42)
+		}, nil
+	})
+
+	res, errs := i.Introspect(func(i ...int) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	got := factory(1).Interface().([]int)
+	if len(got) != 1 || got[0] != 42 {
+		t.Fatalf("expected one-element fallback slice, got %v", got)
+	}
+}
+
+// Write a test that verifies that a variadic parameter's single-element
+// fallback walks the parent chain, the same way any other parameter does,
+// rather than only consulting the child's own default factory.
+//
+// The test should:
+// - give the parent a default factory resolving int but rejecting []int
+// - give the child no default factory of its own
+// - introspect a function taking a variadic ...int parameter using the child
+// - verify the parent's element factory was used as the fallback
+func TestIntrospectVariadicElementFallbackThroughParent(t *testing.T) {
+	parent, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		if r.Kind() == reflect.Slice {
+			return nil, injector.ErrorDepFactoryNotFound
+		}
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(42)
+		}, nil
+	})
+
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child.SetParent(parent)
+
+	res, errs := child.Introspect(func(i ...int) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	got := factory(1).Interface().([]int)
+	if len(got) != 1 || got[0] != 42 {
+		t.Fatalf("expected parent's element factory to be used, got %v", got)
+	}
+}
+
 // Write a test that verifies that the Introspect function returns an error if the default factory function returns an error.
 //
 // The test should:
@@ -226,7 +337,7 @@ func TestIntrospectDefaultFactoryError(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	i.SetDefaultFactory(func(r reflect.Type) (TestFactoryFunc, error) {
+	i.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
 		return nil, errors.New("test error")
 	})
 	_, errs := i.Introspect(func(i int) {})
@@ -234,3 +345,159 @@ func TestIntrospectDefaultFactoryError(t *testing.T) {
 		t.Fatal("expected error to be not nil")
 	}
 }
+
+// Write a test that verifies that SetParent and Parent work as expected.
+//
+// The test should:
+// - create two Introspector instances
+// - call SetParent on the child with the parent
+// - verify that Parent returns the same parent instance
+func TestSetParent(t *testing.T) {
+	parent, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child.SetParent(parent)
+	if child.Parent() != parent {
+		t.Fatal("expected child's parent to be the parent instance")
+	}
+}
+
+// Write a test that verifies that a child introspector can override a
+// parent's factory for the same type.
+//
+// The test should:
+// - register a factory for int on the parent
+// - register a different factory for int on the child
+// - set the child's parent to the parent instance
+// - introspect a function depending on int using the child
+// - verify that the child's factory, not the parent's, was used
+func TestIntrospectParentFactoryOverride(t *testing.T) {
+	parent, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterFactory[int](func(i int) reflect.Value {
+		return reflect.ValueOf(i * 2)
+	}, parent)
+
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterFactory[int](func(i int) reflect.Value {
+		return reflect.ValueOf(i * 3)
+	}, child)
+	child.SetParent(parent)
+
+	res, errs := child.Introspect(func(i int) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	if factory(1).Interface().(int) != 3 {
+		t.Fatal("expected child's own factory to win over the parent's")
+	}
+}
+
+// Write a test that verifies that a child introspector falls through to its
+// parent's factories when it has none registered locally.
+//
+// The test should:
+// - register a factory for int on the parent only
+// - set the child's parent to the parent instance
+// - introspect a function depending on int using the child
+// - verify that the parent's factory was used
+func TestIntrospectParentFallthrough(t *testing.T) {
+	parent, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterFactory[int](func(i int) reflect.Value {
+		return reflect.ValueOf(i * 2)
+	}, parent)
+	parent.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return nil, injector.ErrorDepFactoryNotFound
+	})
+
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return nil, injector.ErrorDepFactoryNotFound
+	})
+	child.SetParent(parent)
+
+	res, errs := child.Introspect(func(i int) {})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	factory := res.FactoryMap()[0]
+	if factory(1).Interface().(int) != 2 {
+		t.Fatal("expected child to fall through to the parent's factory")
+	}
+}
+
+// Write a test that verifies that a child introspector's parent and its
+// parent's factoryMap and defaultFactory can be read (via the child's
+// Introspect) while the child's parent and the parent's factoryMap are
+// written (via SetParent/RegisterFactory), concurrently, without tripping
+// the race detector.
+//
+// The test should:
+//   - run one goroutine repeatedly calling child.SetParent(parent)
+//   - run another goroutine repeatedly calling child.Introspect on a function
+//     depending on int
+//   - run a third goroutine repeatedly registering a factory for int on parent
+//   - wait for all goroutines to finish
+func TestConcurrentSetParentAndIntrospect(t *testing.T) {
+	parent, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent.SetDefaultFactory(func(r reflect.Type, _ string) (TestFactoryFunc, error) {
+		return func(i int) reflect.Value {
+			return reflect.ValueOf(i)
+		}, nil
+	})
+
+	child, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const iterations = 100
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for n := 0; n < iterations; n++ {
+			child.SetParent(parent)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		fn := func(i int) {}
+		for n := 0; n < iterations; n++ {
+			child.Introspect(fn)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for n := 0; n < iterations; n++ {
+			introspector.RegisterFactory[int](func(i int) reflect.Value {
+				return reflect.ValueOf(i)
+			}, parent)
+		}
+	}()
+
+	wg.Wait()
+}
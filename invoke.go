@@ -0,0 +1,117 @@
+package introspector
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+// ErrorNotCallable is returned by Invoke when a factory cannot be called with
+// no arguments to produce a reflect.Value, i.e. it does not follow the
+// func() reflect.Value convention established by InjectorFactoryFunc.
+var ErrorNotCallable = errors.New("introspector: factory is not a niladic reflect.Value factory")
+
+// Renderable is implemented by values an invoked function returns that know
+// how to write themselves out, e.g. an HTTP handler's response body.
+// InvokeAndRender renders the first return value implementing it.
+type Renderable interface {
+	Render(w io.Writer) error
+}
+
+// invoke resolves the arguments for fnType from factoryMap, calling each
+// factory with reflect.Call to produce its reflect.Value. extraArgs override
+// a position whenever its type matches the corresponding parameter type,
+// letting a caller supply fresh per-call values (e.g. http.ResponseWriter)
+// without registering a factory for them. If variadicIndex is not -1, the
+// factory at that index is expected to produce the full variadic slice
+// value, which is passed to fn via CallSlice rather than Call.
+func invoke(fnType reflect.Type, fnVal reflect.Value, factoryMap map[int]any, extraArgs []reflect.Value, variadicIndex int) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+	for idx := 0; idx < fnType.NumIn(); idx++ {
+		paramType := fnType.In(idx)
+		if arg, ok := findExtraArg(extraArgs, paramType); ok {
+			args[idx] = arg
+			continue
+		}
+
+		factory, ok := factoryMap[idx]
+		if !ok {
+			return nil, ErrorFactoryNotFound
+		}
+		arg, err := callFactory(factory)
+		if err != nil {
+			return nil, err
+		}
+		args[idx] = arg
+	}
+	if variadicIndex != -1 {
+		return fnVal.CallSlice(args), nil
+	}
+	return fnVal.Call(args), nil
+}
+
+func findExtraArg(extraArgs []reflect.Value, paramType reflect.Type) (reflect.Value, bool) {
+	for _, arg := range extraArgs {
+		if arg.Type().AssignableTo(paramType) {
+			return arg, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func callFactory(factory any) (reflect.Value, error) {
+	factoryVal := reflect.ValueOf(factory)
+	factoryType := factoryVal.Type()
+	if factoryType.Kind() != reflect.Func || factoryType.NumIn() != 0 || factoryType.NumOut() != 1 || factoryType.Out(0) != reflect.TypeOf(reflect.Value{}) {
+		return reflect.Value{}, ErrorNotCallable
+	}
+	out := factoryVal.Call(nil)[0].Interface().(reflect.Value)
+	return out, nil
+}
+
+func (r *introspectorResult[F]) Invoke(fn any, extraArgs ...reflect.Value) ([]reflect.Value, error) {
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func || fnVal.Type() != r.fnType {
+		return nil, ErrorNotAFunction
+	}
+
+	factoryMap := make(map[int]any, len(r.factoryMap))
+	for idx, factory := range r.factoryMap {
+		factoryMap[idx] = factory
+	}
+	return invoke(r.fnType, fnVal, factoryMap, extraArgs, r.variadicIndex)
+}
+
+// Invoke runs the same introspection Introspect does - and shares its cache -
+// except a parameter whose type matches one of extraArgs is never resolved
+// through a factory at all, so a caller can pass a fresh, per-call value
+// (e.g. http.ResponseWriter) without registering a factory for it.
+func (i *introspector[F]) Invoke(fn any, extraArgs ...reflect.Value) ([]reflect.Value, error) {
+	extraArgTypes := make([]reflect.Type, len(extraArgs))
+	for idx, arg := range extraArgs {
+		extraArgTypes[idx] = arg.Type()
+	}
+
+	result, errs := i.introspect(fn, extraArgTypes)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result.Invoke(fn, extraArgs...)
+}
+
+// InvokeAndRender invokes fn through i and, if any of its return values
+// implements Renderable, writes it to w. This is the composition sugar HTTP
+// handlers built on Introspector typically want: resolve the handler's
+// dependencies, call it, and render whatever it produced.
+func InvokeAndRender[F any](w io.Writer, i Introspector[F], fn any, extraArgs ...reflect.Value) error {
+	results, err := i.Invoke(fn, extraArgs...)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if renderable, ok := result.Interface().(Renderable); ok {
+			return renderable.Render(w)
+		}
+	}
+	return nil
+}
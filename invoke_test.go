@@ -0,0 +1,185 @@
+package introspector_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/infinytum/injector"
+	"github.com/infinytum/introspector"
+)
+
+type TestRenderable struct {
+	Body string
+}
+
+func (r TestRenderable) Render(w io.Writer) error {
+	_, err := w.Write([]byte(r.Body))
+	return err
+}
+
+// Write a test that verifies that Introspector.Invoke resolves the factories
+// for a function's parameters and calls it with the produced values.
+//
+// The test should:
+// - register a factory for TestDependency
+// - call Invoke with a function depending on TestDependency
+// - verify that the function was called with the resolved dependency
+func TestInvoke(t *testing.T) {
+	injector.Singleton(func() TestDependency {
+		return TestDependency{Value: 42}
+	})
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	var called TestDependency
+	results, err := i.Invoke(func(d TestDependency) int {
+		called = d
+		return d.Value
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if called.Value != 42 {
+		t.Fatal("expected function to be called with resolved dependency")
+	}
+	if results[0].Interface().(int) != 42 {
+		t.Fatal("expected return value to be 42")
+	}
+}
+
+// Write a test that verifies that extraArgs override a resolved factory for
+// the matching parameter position.
+//
+// The test should:
+// - register a default factory that would fail for http.ResponseWriter
+// - call Invoke with an extraArg providing a concrete http.ResponseWriter
+// - verify that the extraArg, not a factory, was used
+func TestInvokeExtraArgsOverride(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	rec := httptest.NewRecorder()
+	results, err := i.Invoke(func(w http.ResponseWriter) http.ResponseWriter {
+		return w
+	}, reflect.ValueOf(rec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Interface().(http.ResponseWriter) != rec {
+		t.Fatal("expected extraArg to be passed through")
+	}
+}
+
+// Write a test that verifies that Invoke shares Introspect's per-function
+// cache, so calling Invoke repeatedly on the same function only consults the
+// default factory once.
+//
+// The test should:
+// - set a default factory that counts its calls
+// - call Invoke on the same function twice
+// - verify the default factory was only consulted once
+func TestInvokeCachesResult(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		calls++
+		return func() reflect.Value {
+			return reflect.ValueOf(1)
+		}, nil
+	})
+
+	fn := func(i int) {}
+	if _, err := i.Invoke(fn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.Invoke(fn); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected default factory to be consulted once, got %d", calls)
+	}
+}
+
+// Write a test that verifies that InvokeAndRender renders the first return
+// value implementing Renderable.
+//
+// The test should:
+// - call InvokeAndRender with a function returning a Renderable
+// - verify that the Renderable was written to the given io.Writer
+func TestInvokeAndRender(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.SetDefaultFactory(func(t reflect.Type, _ string) (InjectorFactory, error) {
+		val, err := introspector.InjectorFactoryFunc(t)
+		return func() reflect.Value {
+			return *val
+		}, err
+	})
+
+	var buf bytes.Buffer
+	err = introspector.InvokeAndRender(&buf, i, func() TestRenderable {
+		return TestRenderable{Body: "hello"}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "hello" {
+		t.Fatalf("expected rendered body to be 'hello', got %q", buf.String())
+	}
+}
+
+// Write a test that verifies that Invoke resolves a registered slice factory
+// for a variadic parameter and calls the function with its elements.
+//
+// The test should:
+// - register a factory for []int
+// - call Invoke with a function taking a variadic ...int parameter
+// - verify that the function was called with the slice's elements
+func TestInvokeVariadic(t *testing.T) {
+	i, err := introspector.NewIntrospector[InjectorFactory, InjectorResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterFactory[[]int](func() reflect.Value {
+		return reflect.ValueOf([]int{1, 2, 3})
+	}, i)
+
+	var sum int
+	_, err = i.Invoke(func(nums ...int) {
+		for _, n := range nums {
+			sum += n
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != 6 {
+		t.Fatalf("expected sum 6, got %d", sum)
+	}
+}
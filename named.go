@@ -0,0 +1,73 @@
+package introspector
+
+import "reflect"
+
+// Qualifier is a compile-time marker type used with Named to distinguish two
+// dependencies that share the same underlying Go type. Implementations are
+// typically zero-size structs, e.g.:
+//
+//	type DSNQualifier struct{}
+//	func (DSNQualifier) Name() string { return "dsn" }
+type Qualifier interface {
+	Name() string
+}
+
+// Named wraps a dependency of type T tagged with the compile-time qualifier
+// Tag. A function parameter typed Named[T, Tag] is resolved against the
+// factory registered under FactoryKey{Type: typeof(T), Name: Tag{}.Name()}
+// via RegisterNamedFactory, rather than the plain, unqualified factory for T.
+type Named[T any, Tag Qualifier] struct {
+	Value T
+}
+
+// namedType is implemented by every Named[T, Tag] instantiation so Introspect
+// can recognize a parameter as named without knowing T or Tag.
+type namedType interface {
+	namedTag() (reflect.Type, string)
+}
+
+func (Named[T, Tag]) namedTag() (reflect.Type, string) {
+	var tag Tag
+	return reflect.TypeOf(new(T)).Elem(), tag.Name()
+}
+
+// unwrapNamedType reports whether t is a Named[T, Tag] instantiation and, if
+// so, returns the wrapped dependency type and the qualifier's name.
+func unwrapNamedType(t reflect.Type) (reflect.Type, string, bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, "", false
+	}
+	zero := reflect.New(t).Elem().Interface()
+	named, ok := zero.(namedType)
+	if !ok {
+		return nil, "", false
+	}
+	innerType, name := named.namedTag()
+	return innerType, name, true
+}
+
+// wrapNamedFactory adapts an inner factory (registered for the unwrapped
+// dependency type) into a factory of the same F producing a Named[T, Tag]
+// value instead, so it can be stored and invoked like any other factory.
+// The inner factory's signature is preserved - only its reflect.Value return
+// is rewrapped - so this works regardless of F's calling convention.
+func wrapNamedFactory[F any](inner F, namedType reflect.Type) F {
+	return transformFactory(inner, func(innerResult reflect.Value) reflect.Value {
+		v := reflect.New(namedType).Elem()
+		v.FieldByName("Value").Set(innerResult)
+		return v
+	})
+}
+
+// transformFactory adapts an inner factory of type F into another factory of
+// the same F whose produced reflect.Value has been passed through transform.
+// The inner factory's signature, and thus F's calling convention, is left
+// untouched - only the reflect.Value it returns is rewritten.
+func transformFactory[F any](inner F, transform func(reflect.Value) reflect.Value) F {
+	innerVal := reflect.ValueOf(inner)
+	wrapped := reflect.MakeFunc(innerVal.Type(), func(args []reflect.Value) []reflect.Value {
+		innerResult := innerVal.Call(args)[0].Interface().(reflect.Value)
+		return []reflect.Value{reflect.ValueOf(transform(innerResult))}
+	})
+	return wrapped.Interface().(F)
+}
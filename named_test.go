@@ -0,0 +1,82 @@
+package introspector_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/infinytum/introspector"
+)
+
+type DSNQualifier struct{}
+
+func (DSNQualifier) Name() string { return "dsn" }
+
+type ReplicaDSNQualifier struct{}
+
+func (ReplicaDSNQualifier) Name() string { return "replica-dsn" }
+
+// Write a test that verifies that RegisterNamedFactory lets two dependencies
+// of the same Go type coexist, each resolved by its Named[T, Tag] parameter.
+//
+// The test should:
+// - register two string factories under different names
+// - introspect a function taking both as Named[string, Tag] parameters
+// - verify each parameter resolved to its own named factory
+func TestIntrospectNamedFactory(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterNamedFactory[string]("dsn", func(i int) reflect.Value {
+		return reflect.ValueOf("primary-dsn")
+	}, i)
+	introspector.RegisterNamedFactory[string]("replica-dsn", func(i int) reflect.Value {
+		return reflect.ValueOf("replica-dsn-value")
+	}, i)
+
+	res, errs := i.Introspect(func(primary introspector.Named[string, DSNQualifier], replica introspector.Named[string, ReplicaDSNQualifier]) {
+	})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	primaryFactory, ok := res.FactoryMap()[0]
+	if !ok {
+		t.Fatal("expected factory map to have entry for arg 0")
+	}
+	primary := primaryFactory(1).Interface().(introspector.Named[string, DSNQualifier])
+	if primary.Value != "primary-dsn" {
+		t.Fatalf("expected primary dsn, got %q", primary.Value)
+	}
+
+	replicaFactory, ok := res.FactoryMap()[1]
+	if !ok {
+		t.Fatal("expected factory map to have entry for arg 1")
+	}
+	replica := replicaFactory(1).Interface().(introspector.Named[string, ReplicaDSNQualifier])
+	if replica.Value != "replica-dsn-value" {
+		t.Fatalf("expected replica dsn, got %q", replica.Value)
+	}
+}
+
+// Write a test that verifies that a plain (unnamed) factory for a type
+// doesn't satisfy a Named[T, Tag] parameter for that same type.
+//
+// The test should:
+// - register only the unqualified factory for string
+// - introspect a function depending on Named[string, Tag]
+// - verify that the Introspect function returns an error
+func TestIntrospectNamedFactoryMissing(t *testing.T) {
+	i, err := introspector.NewIntrospector[TestFactoryFunc, TestResult]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspector.RegisterFactory[string](func(i int) reflect.Value {
+		return reflect.ValueOf("unqualified")
+	}, i)
+
+	_, errs := i.Introspect(func(d introspector.Named[string, DSNQualifier]) {})
+	if len(errs) == 0 {
+		t.Fatal("expected error to be not nil")
+	}
+}
@@ -0,0 +1,38 @@
+package introspector
+
+import "reflect"
+
+// resolveVariadic resolves the factory for a variadic parameter whose
+// reflect.Type is the slice type of its element (e.g. []int for ...int), as
+// reported by reflect.Type.In for the last parameter of a variadic function.
+//
+// It first tries to resolve the slice type itself, so callers can register a
+// whole batch of values at once via RegisterFactory[[]T]. If that fails, it
+// falls back to resolving a single element of type T the same way as any
+// other parameter (explicit factory, default factory, then the parent chain)
+// and wraps the result in a one-element slice, so a variadic
+// parameter behaves like an ordinary dependency when only one is available.
+func (i *introspector[F]) resolveVariadic(sliceType reflect.Type) (F, error) {
+	if factory, err := i.resolve(sliceType, ""); err == nil {
+		return factory, nil
+	}
+
+	elemFactory, err := i.resolve(sliceType.Elem(), "")
+	if err != nil {
+		var zero F
+		return zero, err
+	}
+	return wrapVariadicFactory(elemFactory, sliceType), nil
+}
+
+// wrapVariadicFactory adapts a factory for a single element into a factory
+// of the same F producing a one-element slice of sliceType instead, so a
+// single resolved dependency can be passed to a variadic parameter via
+// reflect.Value.CallSlice.
+func wrapVariadicFactory[F any](elem F, sliceType reflect.Type) F {
+	return transformFactory(elem, func(elemResult reflect.Value) reflect.Value {
+		slice := reflect.MakeSlice(sliceType, 1, 1)
+		slice.Index(0).Set(elemResult)
+		return slice
+	})
+}